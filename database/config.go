@@ -0,0 +1,111 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// Config 连接池与DSN配置
+type Config struct {
+	IP       string
+	Port     int
+	UserName string
+	Passwd   string
+	DBName   string
+
+	MaxOpen         int           // 最大打开连接数，0表示不限制
+	MaxIdle         int           // 最大空闲连接数
+	ConnMaxLifetime time.Duration // 连接最大可复用时长，0表示使用默认的30秒
+	ConnMaxIdleTime time.Duration // 连接最大空闲时长，0表示不限制
+
+	ParseTime bool           // 是否将DATE/DATETIME/TIMESTAMP解析为time.Time
+	Loc       *time.Location // 解析时间使用的时区，默认为time.UTC
+	Charset   string         // 字符集，默认为utf8mb4
+	Collation string         // 排序规则
+	TLS       string         // TLS配置名，参见mysql.RegisterTLSConfig，留空表示不使用TLS
+	Params    map[string]string
+
+	ConnectTimeout time.Duration
+	ReadTimeout    time.Duration
+	WriteTimeout   time.Duration
+}
+
+// NewMySQLWithConfig 使用Config创建MySQL数据库，DSN通过mysql.Config/FormatDSN构建，
+// 支持TLS、UTC时间解析及连接池大小配置
+func NewMySQLWithConfig(cfg Config) (m *MySQL, err error) {
+	m = new(MySQL)
+	m.DatabaseType = dbTypeMysql
+	m.QueryTimeout = 5
+	m.IP = cfg.IP
+	m.Port = cfg.Port
+	m.UserName = cfg.UserName
+	m.Passwd = cfg.Passwd
+	m.DBName = cfg.DBName
+	m.ParseTime = cfg.ParseTime
+
+	dsn := buildDSN(cfg)
+	db, err := sql.Open(m.DatabaseType, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.MaxOpen > 0 {
+		db.SetMaxOpenConns(cfg.MaxOpen)
+	}
+	if cfg.MaxIdle > 0 {
+		db.SetMaxIdleConns(cfg.MaxIdle)
+	}
+	if cfg.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	} else {
+		db.SetConnMaxLifetime(time.Second * 30)
+	}
+	if cfg.ConnMaxIdleTime > 0 {
+		db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	}
+
+	m.stmtDB = db
+	return
+}
+
+func buildDSN(cfg Config) string {
+	driverCfg := mysql.NewConfig()
+	driverCfg.Net = "tcp"
+	driverCfg.Addr = fmt.Sprintf("%s:%d", cfg.IP, cfg.Port)
+	driverCfg.User = cfg.UserName
+	driverCfg.Passwd = cfg.Passwd
+	driverCfg.DBName = cfg.DBName
+
+	driverCfg.ParseTime = cfg.ParseTime
+	if cfg.Loc != nil {
+		driverCfg.Loc = cfg.Loc
+	}
+	if cfg.Collation != "" {
+		driverCfg.Collation = cfg.Collation
+	}
+	if cfg.TLS != "" {
+		driverCfg.TLSConfig = cfg.TLS
+	}
+	if cfg.ConnectTimeout > 0 {
+		driverCfg.Timeout = cfg.ConnectTimeout
+	}
+	if cfg.ReadTimeout > 0 {
+		driverCfg.ReadTimeout = cfg.ReadTimeout
+	}
+	if cfg.WriteTimeout > 0 {
+		driverCfg.WriteTimeout = cfg.WriteTimeout
+	}
+
+	driverCfg.Params = make(map[string]string)
+	if cfg.Charset != "" {
+		driverCfg.Params["charset"] = cfg.Charset
+	}
+	for k, v := range cfg.Params {
+		driverCfg.Params[k] = v
+	}
+
+	return driverCfg.FormatDSN()
+}
@@ -0,0 +1,90 @@
+package database
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildDSNIncludesConnectionIdentity(t *testing.T) {
+	dsn := buildDSN(Config{
+		IP:       "127.0.0.1",
+		Port:     3306,
+		UserName: "root",
+		Passwd:   "secret",
+		DBName:   "app",
+	})
+
+	want := "root:secret@tcp(127.0.0.1:3306)/app"
+	if !strings.HasPrefix(dsn, want) {
+		t.Fatalf("buildDSN() = %q, want prefix %q", dsn, want)
+	}
+}
+
+func TestBuildDSNAppliesTLSCharsetCollationAndParams(t *testing.T) {
+	dsn := buildDSN(Config{
+		IP:        "10.0.0.1",
+		Port:      3306,
+		UserName:  "app",
+		Passwd:    "pw",
+		DBName:    "db",
+		ParseTime: true,
+		Charset:   "utf8mb4",
+		Collation: "utf8mb4_unicode_ci",
+		TLS:       "custom",
+		Params:    map[string]string{"interpolateParams": "true"},
+	})
+
+	for _, want := range []string{
+		"parseTime=true",
+		"charset=utf8mb4",
+		"collation=utf8mb4_unicode_ci",
+		"tls=custom",
+		"interpolateParams=true",
+	} {
+		if !strings.Contains(dsn, want) {
+			t.Errorf("buildDSN() = %q, want it to contain %q", dsn, want)
+		}
+	}
+}
+
+func TestBuildDSNAppliesTimeouts(t *testing.T) {
+	dsn := buildDSN(Config{
+		IP:             "10.0.0.1",
+		Port:           3306,
+		UserName:       "app",
+		Passwd:         "pw",
+		DBName:         "db",
+		ConnectTimeout: 2 * time.Second,
+		ReadTimeout:    3 * time.Second,
+		WriteTimeout:   4 * time.Second,
+	})
+
+	for _, want := range []string{"timeout=2s", "readTimeout=3s", "writeTimeout=4s"} {
+		if !strings.Contains(dsn, want) {
+			t.Errorf("buildDSN() = %q, want it to contain %q", dsn, want)
+		}
+	}
+}
+
+func TestNewMySQLWithConfigSetsFieldsFromConfig(t *testing.T) {
+	m, err := NewMySQLWithConfig(Config{
+		IP:        "127.0.0.1",
+		Port:      3306,
+		UserName:  "root",
+		Passwd:    "secret",
+		DBName:    "app",
+		ParseTime: true,
+		MaxOpen:   5,
+		MaxIdle:   2,
+	})
+	if err != nil {
+		t.Fatalf("NewMySQLWithConfig failed: %s", err)
+	}
+	defer m.Close()
+
+	if m.IP != "127.0.0.1" || m.Port != 3306 || m.UserName != "root" ||
+		m.Passwd != "secret" || m.DBName != "app" || !m.ParseTime {
+		t.Fatalf("NewMySQLWithConfig did not populate MySQL fields from Config: %+v", m)
+	}
+}
@@ -0,0 +1,39 @@
+package database
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// NullDecimal 保存DECIMAL列的原始文本形式，避免通过float64转换丢失精度
+type NullDecimal struct {
+	String string
+	Valid  bool
+}
+
+// Scan 实现sql.Scanner接口
+func (n *NullDecimal) Scan(value interface{}) error {
+	if value == nil {
+		n.String, n.Valid = "", false
+		return nil
+	}
+
+	n.Valid = true
+	switch v := value.(type) {
+	case string:
+		n.String = v
+	case []byte:
+		n.String = string(v)
+	default:
+		return fmt.Errorf("unsupported decimal scan type %T", value)
+	}
+	return nil
+}
+
+// Value 实现driver.Valuer接口
+func (n NullDecimal) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.String, nil
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+// NullUint64 保存UNSIGNED整型列的值。go-sql-driver/mysql按文本协议将无符号整型
+// 解码为uint64，若借用sql.NullInt64接收会在超出int64范围时报错，因此需要单独的接收者
+type NullUint64 struct {
+	Uint64 uint64
+	Valid  bool
+}
+
+// Scan 实现sql.Scanner接口
+func (n *NullUint64) Scan(value interface{}) error {
+	if value == nil {
+		n.Uint64, n.Valid = 0, false
+		return nil
+	}
+
+	n.Valid = true
+	switch v := value.(type) {
+	case uint64:
+		n.Uint64 = v
+	case int64:
+		n.Uint64 = uint64(v)
+	case []byte:
+		parsed, err := strconv.ParseUint(string(v), 10, 64)
+		if err != nil {
+			return err
+		}
+		n.Uint64 = parsed
+	case string:
+		parsed, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return err
+		}
+		n.Uint64 = parsed
+	default:
+		return fmt.Errorf("unsupported uint64 scan type %T", value)
+	}
+	return nil
+}
+
+// Value 实现driver.Valuer接口
+func (n NullUint64) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Uint64, nil
+}
@@ -38,11 +38,12 @@ type UnanimityHostWithDomains struct {
 
 // Field 字段
 type Field struct {
-	Name string
-	Type string
+	Name   string
+	Type   string
+	DBType string // 数据库原始列类型，如VARCHAR/DECIMAL/JSON，供RegisterTypeMapping按需扩展使用
 }
 
-// FieldType Common type include "STRING", "FLOAT", "INT", "BOOL"
+// FieldType Common type include "string", "int64", "uint64", "float64", "bool", "decimal", "time", "bytes", "json"
 func (f *Field) FieldType() string {
 	return f.Type
 }
@@ -82,6 +83,7 @@ type MySQL struct {
 	DBName         string
 	ConnectTimeout int
 	QueryTimeout   int
+	ParseTime      bool // 是否将DATE/DATETIME/TIMESTAMP列解析为time.Time，默认为false以保持原文本
 	stmtDB         *sql.DB
 }
 
@@ -154,136 +156,132 @@ func (m *MySQL) QueryRows(querySQL string) (queryRows *QueryRows, err error) {
 
 	fields := make([]Field, 0, len(colTypes))
 	for _, colType := range colTypes {
-		fields = append(fields, Field{Name: colType.Name(), Type: getDataType(colType.DatabaseTypeName())})
+		fields = append(fields, Field{
+			Name:   colType.Name(),
+			Type:   getDataType(colType.DatabaseTypeName(), m.ParseTime),
+			DBType: colType.DatabaseTypeName(),
+		})
 	}
 
 	queryRows = newQueryRows()
 	queryRows.Fields = fields
 	for rawRows.Next() {
-		receiver := createReceiver(fields)
+		receiver := createReceiver(fields, m.ParseTime)
 		err = rawRows.Scan(receiver...)
 		if err != nil {
 			err = fmt.Errorf("scan rows failed <-- %s", err.Error())
 			return
 		}
 
-		queryRows.Records = append(queryRows.Records, getRecordFromReceiver(receiver, fields))
+		queryRows.Records = append(queryRows.Records, getRecordFromReceiver(receiver, fields, m.ParseTime))
 	}
 	return
 }
 
-func createReceiver(fields []Field) (receiver []interface{}) {
+// createReceiver 按字段的数据库原始类型构造用于sql.Rows.Scan的接收者，
+// parseTime控制DATE/DATETIME/TIMESTAMP是按time.Time还是按原文本接收
+func createReceiver(fields []Field, parseTime bool) (receiver []interface{}) {
 	receiver = make([]interface{}, 0, len(fields))
 	for _, field := range fields {
-		switch field.Type {
-		case "string":
-			{
-				var val sql.NullString
-				receiver = append(receiver, &val)
-			}
-		case "int64":
-			{
-				var val sql.NullInt64
-				receiver = append(receiver, &val)
-			}
-		case "float64":
-			{
-				var val sql.NullFloat64
-				receiver = append(receiver, &val)
-			}
-		case "bool":
-			{
-				var val sql.NullBool
-				receiver = append(receiver, &val)
-			}
-		default:
-			var val sql.NullString
-			receiver = append(receiver, &val)
-		}
+		receiver = append(receiver, receiverFor(field.DBType, parseTime)())
 	}
 
 	return
 }
 
-func getRecordFromReceiver(receiver []interface{}, fields []Field) (record map[string]interface{}) {
+func getRecordFromReceiver(receiver []interface{}, fields []Field, parseTime bool) (record map[string]interface{}) {
 	record = make(map[string]interface{})
 	for idx := 0; idx < len(fields); idx++ {
 		field := fields[idx]
-		value := receiver[idx]
-		switch field.Type {
-		case "string":
-			{
-				nullVal := value.(*sql.NullString)
-				record[field.Name] = nil
-				if nullVal.Valid {
-					record[field.Name] = nullVal.String
-				}
-			}
-		case "int64":
-			{
-				nullVal := value.(*sql.NullInt64)
-				record[field.Name] = nil
-				if nullVal.Valid {
-					record[field.Name] = nullVal.Int64
-				}
-			}
-		case "float64":
-			{
-				nullVal := value.(*sql.NullFloat64)
-				record[field.Name] = nil
-				if nullVal.Valid {
-					record[field.Name] = nullVal.Float64
-				}
-			}
-		case "bool":
-			{
-				nullVal := value.(*sql.NullBool)
-				record[field.Name] = nil
-				if nullVal.Valid {
-					record[field.Name] = nullVal.Bool
-				}
-			}
-		default:
-			nullVal := value.(*sql.NullString)
-			record[field.Name] = nil
-			if nullVal.Valid {
-				record[field.Name] = nullVal.String
-			}
+		record[field.Name] = extractFor(field.DBType, parseTime)(receiver[idx])
+	}
+	return
+}
+
+// QueryRow 执行MySQL Query语句，返回１条或０条数据
+func (m *MySQL) QueryRow(stmt string) (row *QueryRow, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("query row failed <-- %s", err.Error())
 		}
+	}()
+
+	queryRows, err := m.QueryRows(stmt)
+	if err != nil || queryRows == nil {
+		return
 	}
+
+	if len(queryRows.Records) < 1 {
+		return
+	}
+
+	row = newQueryRow()
+	row.Fields = queryRows.Fields
+	row.Record = queryRows.Records[0]
+
 	return
 }
 
-func getDataType(dbColType string) (colType string) {
-	var columnTypeDict = map[string]string{
-		"VARCHAR":  "string",
-		"TEXT":     "string",
-		"NVARCHAR": "string",
-		"DATETIME": "string",
-		"DECIMAL":  "float64",
-		"BOOL":     "bool",
-		"INT":      "int64",
-		"BIGINT":   "int64",
+// QueryRowsWithArgs 使用预编译语句执行MySQL Query语句，返回多条数据，参数通过占位符`?`安全绑定
+func (m *MySQL) QueryRowsWithArgs(ctx context.Context, querySQL string, args ...interface{}) (queryRows *QueryRows, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("query rows on %s:%d failed <-- %s", m.IP, m.Port, err.Error())
+		}
+	}()
+
+	stmt, err := m.stmtDB.PrepareContext(ctx, querySQL)
+	if err != nil {
+		return
 	}
+	defer stmt.Close()
 
-	colType, ok := columnTypeDict[dbColType]
-	if ok {
+	rawRows, err := stmt.QueryContext(ctx, args...)
+	if rawRows != nil {
+		defer rawRows.Close()
+	}
+	if err != nil {
 		return
 	}
 
-	colType = "string"
+	colTypes, err := rawRows.ColumnTypes()
+	if err != nil {
+		return
+	}
+
+	fields := make([]Field, 0, len(colTypes))
+	for _, colType := range colTypes {
+		fields = append(fields, Field{
+			Name:   colType.Name(),
+			Type:   getDataType(colType.DatabaseTypeName(), m.ParseTime),
+			DBType: colType.DatabaseTypeName(),
+		})
+	}
+
+	queryRows = newQueryRows()
+	queryRows.Fields = fields
+	for rawRows.Next() {
+		receiver := createReceiver(fields, m.ParseTime)
+		err = rawRows.Scan(receiver...)
+		if err != nil {
+			err = fmt.Errorf("scan rows failed <-- %s", err.Error())
+			return
+		}
+
+		queryRows.Records = append(queryRows.Records, getRecordFromReceiver(receiver, fields, m.ParseTime))
+	}
 	return
 }
 
-// QueryRow 执行MySQL Query语句，返回１条或０条数据
-func (m *MySQL) QueryRow(stmt string) (row *QueryRow, err error) {
+// QueryRowWithArgs 使用预编译语句执行MySQL Query语句，返回１条或０条数据，参数通过占位符`?`安全绑定
+func (m *MySQL) QueryRowWithArgs(ctx context.Context, querySQL string, args ...interface{}) (row *QueryRow, err error) {
 	defer func() {
 		if err != nil {
 			err = fmt.Errorf("query row failed <-- %s", err.Error())
 		}
 	}()
 
-	queryRows, err := m.QueryRows(stmt)
+	queryRows, err := m.QueryRowsWithArgs(ctx, querySQL, args...)
 	if err != nil || queryRows == nil {
 		return
 	}
@@ -299,6 +297,34 @@ func (m *MySQL) QueryRow(stmt string) (row *QueryRow, err error) {
 	return
 }
 
+// Exec 使用预编译语句执行MySQL的INSERT/UPDATE/DELETE语句，参数通过占位符`?`安全绑定
+func (m *MySQL) Exec(ctx context.Context, execSQL string, args ...interface{}) (affected, lastInsertID int64, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("exec on %s:%d failed <-- %s", m.IP, m.Port, err.Error())
+		}
+	}()
+
+	stmt, err := m.stmtDB.PrepareContext(ctx, execSQL)
+	if err != nil {
+		return
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, args...)
+	if err != nil {
+		return
+	}
+
+	affected, err = result.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	lastInsertID, err = result.LastInsertId()
+	return
+}
+
 func (m *MySQL) fillConnStr() string {
 	dbServerInfoStr := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s",
 		m.UserName, m.Passwd, m.IP, m.Port, m.DBName)
@@ -308,4 +334,4 @@ func (m *MySQL) fillConnStr() string {
 	}
 
 	return dbServerInfoStr
-}
\ No newline at end of file
+}
@@ -0,0 +1,202 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+)
+
+// argsEchoDriver is a minimal fake driver whose Query echoes the bound args
+// back as a single row, so tests can prove QueryRowsWithArgs/QueryRowWithArgs
+// actually bind `?` placeholders through PrepareContext/stmt.QueryContext
+// rather than relying on string concatenation at the call site.
+type argsEchoDriver struct{}
+
+func (argsEchoDriver) Open(name string) (driver.Conn, error) {
+	return argsEchoConn{}, nil
+}
+
+type argsEchoConn struct{}
+
+func (argsEchoConn) Prepare(query string) (driver.Stmt, error) {
+	return argsEchoStmt{}, nil
+}
+func (argsEchoConn) Close() error              { return nil }
+func (argsEchoConn) Begin() (driver.Tx, error) { return nil, errors.New("tx not supported") }
+
+type argsEchoStmt struct{}
+
+func (argsEchoStmt) Close() error  { return nil }
+func (argsEchoStmt) NumInput() int { return -1 }
+func (argsEchoStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("exec not supported by argsEchoStmt")
+}
+func (argsEchoStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &argsEchoRows{row: args}, nil
+}
+
+type argsEchoRows struct {
+	row  []driver.Value
+	done bool
+}
+
+func (r *argsEchoRows) Columns() []string { return []string{"echoed"} }
+func (r *argsEchoRows) Close() error      { return nil }
+func (r *argsEchoRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	dest[0] = r.row[0]
+	r.done = true
+	return nil
+}
+
+func TestQueryRowsWithArgsBindsPlaceholderArgs(t *testing.T) {
+	sql.Register("argsEchoTestDriverRows", argsEchoDriver{})
+	db, err := sql.Open("argsEchoTestDriverRows", "")
+	if err != nil {
+		t.Fatalf("open fake driver failed: %s", err)
+	}
+	defer db.Close()
+
+	m := &MySQL{stmtDB: db}
+	rows, err := m.QueryRowsWithArgs(context.Background(), "SELECT name FROM users WHERE id = ?", "alice")
+	if err != nil {
+		t.Fatalf("QueryRowsWithArgs failed: %s", err)
+	}
+	if len(rows.Records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(rows.Records))
+	}
+	if got := rows.Records[0]["echoed"]; got != "alice" {
+		t.Fatalf("expected bound arg %q to reach the driver, got %v", "alice", got)
+	}
+}
+
+func TestQueryRowWithArgsBindsPlaceholderArgs(t *testing.T) {
+	sql.Register("argsEchoTestDriverRow", argsEchoDriver{})
+	db, err := sql.Open("argsEchoTestDriverRow", "")
+	if err != nil {
+		t.Fatalf("open fake driver failed: %s", err)
+	}
+	defer db.Close()
+
+	m := &MySQL{stmtDB: db}
+	row, err := m.QueryRowWithArgs(context.Background(), "SELECT name FROM users WHERE id = ?", "bob")
+	if err != nil {
+		t.Fatalf("QueryRowWithArgs failed: %s", err)
+	}
+	if got := row.Record["echoed"]; got != "bob" {
+		t.Fatalf("expected bound arg %q to reach the driver, got %v", "bob", got)
+	}
+}
+
+// execDriver is a minimal fake driver whose Exec returns a result derived
+// from the bound args, so tests can prove Exec binds args through
+// PrepareContext/stmt.ExecContext and surfaces RowsAffected/LastInsertId.
+type execDriver struct{}
+
+func (execDriver) Open(name string) (driver.Conn, error) {
+	return execConn{}, nil
+}
+
+type execConn struct{}
+
+func (execConn) Prepare(query string) (driver.Stmt, error) { return execStmt{}, nil }
+func (execConn) Close() error                              { return nil }
+func (execConn) Begin() (driver.Tx, error)                 { return nil, errors.New("tx not supported") }
+
+type execStmt struct{}
+
+func (execStmt) Close() error  { return nil }
+func (execStmt) NumInput() int { return -1 }
+func (execStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return execResult{lastInsertID: args[0].(int64)}, nil
+}
+func (execStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, errors.New("query not supported by execStmt")
+}
+
+type execResult struct{ lastInsertID int64 }
+
+func (r execResult) LastInsertId() (int64, error) { return r.lastInsertID, nil }
+func (r execResult) RowsAffected() (int64, error) { return 3, nil }
+
+func TestExecReturnsAffectedAndLastInsertID(t *testing.T) {
+	sql.Register("execTestDriver", execDriver{})
+	db, err := sql.Open("execTestDriver", "")
+	if err != nil {
+		t.Fatalf("open fake driver failed: %s", err)
+	}
+	defer db.Close()
+
+	m := &MySQL{stmtDB: db}
+	affected, lastInsertID, err := m.Exec(context.Background(), "INSERT INTO t (id) VALUES (?)", int64(7))
+	if err != nil {
+		t.Fatalf("Exec failed: %s", err)
+	}
+	if affected != 3 {
+		t.Fatalf("affected = %d, want 3", affected)
+	}
+	if lastInsertID != 7 {
+		t.Fatalf("lastInsertID = %d, want 7", lastInsertID)
+	}
+}
+
+// queryErrorDriver is a minimal fake driver whose Query always fails, so
+// tests can prove QueryRowsWithArgs still closes the prepared statement
+// (via its deferred stmt.Close()) instead of leaking it on a query error.
+type queryErrorDriver struct {
+	closes int32
+}
+
+func (d *queryErrorDriver) Open(name string) (driver.Conn, error) {
+	return &queryErrorConn{driver: d}, nil
+}
+
+type queryErrorConn struct {
+	driver *queryErrorDriver
+}
+
+func (c *queryErrorConn) Prepare(query string) (driver.Stmt, error) {
+	return &queryErrorStmt{conn: c}, nil
+}
+func (c *queryErrorConn) Close() error              { return nil }
+func (c *queryErrorConn) Begin() (driver.Tx, error) { return nil, errors.New("tx not supported") }
+
+type queryErrorStmt struct {
+	conn *queryErrorConn
+}
+
+func (s *queryErrorStmt) Close() error {
+	atomic.AddInt32(&s.conn.driver.closes, 1)
+	return nil
+}
+func (s *queryErrorStmt) NumInput() int { return -1 }
+func (s *queryErrorStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("exec not supported by queryErrorStmt")
+}
+func (s *queryErrorStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, errors.New("boom")
+}
+
+func TestQueryRowsWithArgsClosesStmtOnQueryError(t *testing.T) {
+	drv := &queryErrorDriver{}
+	sql.Register("queryErrorTestDriver", drv)
+	db, err := sql.Open("queryErrorTestDriver", "")
+	if err != nil {
+		t.Fatalf("open fake driver failed: %s", err)
+	}
+	defer db.Close()
+
+	m := &MySQL{stmtDB: db}
+	if _, err := m.QueryRowsWithArgs(context.Background(), "SELECT 1"); err == nil {
+		t.Fatal("expected QueryRowsWithArgs to return an error")
+	}
+	if got := atomic.LoadInt32(&drv.closes); got != 1 {
+		t.Fatalf("expected the prepared statement to be closed exactly once, closed %d times", got)
+	}
+}
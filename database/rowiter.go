@@ -0,0 +1,127 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+)
+
+// RowIter 流式遍历查询结果，避免一次性把所有记录物化到内存中，
+// 适用于大表或ETL场景
+type RowIter struct {
+	stmt      *sql.Stmt
+	rawRows   *sql.Rows
+	fields    []Field
+	receiver  []interface{}
+	parseTime bool
+	err       error
+}
+
+// IterRows 执行MySQL Query语句，返回逐行遍历的RowIter
+func (m *MySQL) IterRows(ctx context.Context, querySQL string, args ...interface{}) (iter *RowIter, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("iter rows on %s:%d failed <-- %s", m.IP, m.Port, err.Error())
+		}
+	}()
+
+	stmt, err := m.stmtDB.PrepareContext(ctx, querySQL)
+	if err != nil {
+		return
+	}
+
+	rawRows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		stmt.Close()
+		return
+	}
+
+	colTypes, err := rawRows.ColumnTypes()
+	if err != nil {
+		rawRows.Close()
+		stmt.Close()
+		return
+	}
+
+	fields := make([]Field, 0, len(colTypes))
+	for _, colType := range colTypes {
+		fields = append(fields, Field{
+			Name:   colType.Name(),
+			Type:   getDataType(colType.DatabaseTypeName(), m.ParseTime),
+			DBType: colType.DatabaseTypeName(),
+		})
+	}
+
+	iter = &RowIter{
+		stmt:      stmt,
+		rawRows:   rawRows,
+		fields:    fields,
+		receiver:  createReceiver(fields, m.ParseTime),
+		parseTime: m.ParseTime,
+	}
+	return
+}
+
+// Next 移动到下一行，没有更多数据或发生错误时返回false，随后应调用Err检查错误
+func (ri *RowIter) Next() bool {
+	if ri.err != nil {
+		return false
+	}
+
+	if !ri.rawRows.Next() {
+		ri.err = ri.rawRows.Err()
+		return false
+	}
+
+	if err := ri.rawRows.Scan(ri.receiver...); err != nil {
+		ri.err = fmt.Errorf("scan row failed <-- %s", err.Error())
+		return false
+	}
+	return true
+}
+
+// Scan 将当前行直接扫描进调用方提供的目标变量，与database/sql.Rows.Scan用法一致
+func (ri *RowIter) Scan(dest ...interface{}) error {
+	return ri.rawRows.Scan(dest...)
+}
+
+// ScanMap 返回当前行的map[string]interface{}表示
+func (ri *RowIter) ScanMap() (map[string]interface{}, error) {
+	return getRecordFromReceiver(ri.receiver, ri.fields, ri.parseTime), nil
+}
+
+// ScanInto 将当前行按列名匹配到structPtr(*T)的字段中，匹配规则同QueryRow.ScanInto
+func (ri *RowIter) ScanInto(structPtr interface{}) error {
+	destVal := reflect.ValueOf(structPtr)
+	if destVal.Kind() != reflect.Ptr || destVal.IsNil() {
+		return fmt.Errorf("scan into requires a non-nil pointer to a struct, got %T", structPtr)
+	}
+
+	structVal := destVal.Elem()
+	if structVal.Kind() != reflect.Struct {
+		return fmt.Errorf("scan into requires a pointer to a struct, got %T", structPtr)
+	}
+
+	record := getRecordFromReceiver(ri.receiver, ri.fields, ri.parseTime)
+	fieldIndex := fieldIndexForType(structVal.Type())
+	return setFieldsFromRecord(structVal, fieldIndex, record)
+}
+
+// Err 返回遍历过程中遇到的错误
+func (ri *RowIter) Err() error {
+	return ri.err
+}
+
+// Close 关闭底层的Rows和预编译语句
+func (ri *RowIter) Close() (err error) {
+	if ri.rawRows != nil {
+		err = ri.rawRows.Close()
+	}
+	if ri.stmt != nil {
+		if stmtErr := ri.stmt.Close(); stmtErr != nil && err == nil {
+			err = stmtErr
+		}
+	}
+	return
+}
@@ -0,0 +1,175 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+)
+
+// reuseBufDriver is a minimal fake driver whose rows hand back the SAME
+// underlying buffers for every row (mutated in place between rows), the way
+// a real driver may reuse its read buffer across Next calls. This exercises
+// RowIter's single-receiver-reused-per-row design: ScanMap must copy out of
+// the receiver before the next row's Scan overwrites it.
+type reuseBufDriver struct{}
+
+func (reuseBufDriver) Open(name string) (driver.Conn, error) {
+	return reuseBufConn{}, nil
+}
+
+type reuseBufConn struct{}
+
+func (reuseBufConn) Prepare(query string) (driver.Stmt, error) { return reuseBufStmt{}, nil }
+func (reuseBufConn) Close() error                              { return nil }
+func (reuseBufConn) Begin() (driver.Tx, error)                 { return nil, errors.New("tx not supported") }
+
+type reuseBufStmt struct{}
+
+func (reuseBufStmt) Close() error  { return nil }
+func (reuseBufStmt) NumInput() int { return -1 }
+func (reuseBufStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("exec not supported by reuseBufStmt")
+}
+func (reuseBufStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &reuseBufRows{
+		blobBuf: make([]byte, 4),
+		jsonBuf: make([]byte, 7),
+	}, nil
+}
+
+type reuseBufRows struct {
+	blobBuf []byte
+	jsonBuf []byte
+	stage   int
+}
+
+func (r *reuseBufRows) Columns() []string { return []string{"blob_col", "json_col"} }
+func (r *reuseBufRows) ColumnTypeDatabaseTypeName(index int) string {
+	return []string{"BLOB", "JSON"}[index]
+}
+func (r *reuseBufRows) Close() error { return nil }
+func (r *reuseBufRows) Next(dest []driver.Value) error {
+	switch r.stage {
+	case 0:
+		copy(r.blobBuf, "AAAA")
+		copy(r.jsonBuf, `{"v":1}`)
+	case 1:
+		// Mutate the SAME backing arrays in place instead of allocating fresh
+		// ones, simulating a driver that reuses its read buffer per row.
+		copy(r.blobBuf, "BBBB")
+		copy(r.jsonBuf, `{"v":2}`)
+	default:
+		return io.EOF
+	}
+	dest[0] = r.blobBuf
+	dest[1] = r.jsonBuf
+	r.stage++
+	return nil
+}
+
+func TestRowIterScanMapDoesNotAliasReusedReceiverAcrossRows(t *testing.T) {
+	sql.Register("reuseBufTestDriver", reuseBufDriver{})
+	db, err := sql.Open("reuseBufTestDriver", "")
+	if err != nil {
+		t.Fatalf("open fake driver failed: %s", err)
+	}
+	defer db.Close()
+
+	m := &MySQL{stmtDB: db}
+	iter, err := m.IterRows(context.Background(), "SELECT blob_col, json_col FROM t")
+	if err != nil {
+		t.Fatalf("IterRows failed: %s", err)
+	}
+	defer iter.Close()
+
+	if !iter.Next() {
+		t.Fatalf("expected first row, Err: %s", iter.Err())
+	}
+	row1, err := iter.ScanMap()
+	if err != nil {
+		t.Fatalf("ScanMap failed: %s", err)
+	}
+
+	if !iter.Next() {
+		t.Fatalf("expected second row, Err: %s", iter.Err())
+	}
+	row2, err := iter.ScanMap()
+	if err != nil {
+		t.Fatalf("ScanMap failed: %s", err)
+	}
+
+	if got := string(row1["blob_col"].([]byte)); got != "AAAA" {
+		t.Fatalf("row1 blob_col was clobbered by the second row's scan: got %q, want %q", got, "AAAA")
+	}
+	if got := string(row2["blob_col"].([]byte)); got != "BBBB" {
+		t.Fatalf("row2 blob_col = %q, want %q", got, "BBBB")
+	}
+	if got := string(row1["json_col"].(json.RawMessage)); got != `{"v":1}` {
+		t.Fatalf("row1 json_col was clobbered by the second row's scan: got %q, want %q", got, `{"v":1}`)
+	}
+	if got := string(row2["json_col"].(json.RawMessage)); got != `{"v":2}` {
+		t.Fatalf("row2 json_col = %q, want %q", got, `{"v":2}`)
+	}
+}
+
+// queryErrorIterDriver is a minimal fake driver whose Query always fails, so
+// tests can prove IterRows closes the prepared statement on the QueryContext
+// error path instead of leaking it.
+type queryErrorIterDriver struct {
+	closes int32
+}
+
+func (d *queryErrorIterDriver) Open(name string) (driver.Conn, error) {
+	return &queryErrorIterConn{driver: d}, nil
+}
+
+type queryErrorIterConn struct {
+	driver *queryErrorIterDriver
+}
+
+func (c *queryErrorIterConn) Prepare(query string) (driver.Stmt, error) {
+	return &queryErrorIterStmt{conn: c}, nil
+}
+func (c *queryErrorIterConn) Close() error { return nil }
+func (c *queryErrorIterConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("tx not supported")
+}
+
+type queryErrorIterStmt struct {
+	conn *queryErrorIterConn
+}
+
+func (s *queryErrorIterStmt) Close() error {
+	atomic.AddInt32(&s.conn.driver.closes, 1)
+	return nil
+}
+func (s *queryErrorIterStmt) NumInput() int { return -1 }
+func (s *queryErrorIterStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("exec not supported by queryErrorIterStmt")
+}
+func (s *queryErrorIterStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, errors.New("boom")
+}
+
+func TestIterRowsClosesStmtOnQueryError(t *testing.T) {
+	drv := &queryErrorIterDriver{}
+	sql.Register("queryErrorIterTestDriver", drv)
+	db, err := sql.Open("queryErrorIterTestDriver", "")
+	if err != nil {
+		t.Fatalf("open fake driver failed: %s", err)
+	}
+	defer db.Close()
+
+	m := &MySQL{stmtDB: db}
+	if _, err := m.IterRows(context.Background(), "SELECT 1"); err == nil {
+		t.Fatal("expected IterRows to return an error")
+	}
+	if got := atomic.LoadInt32(&drv.closes); got != 1 {
+		t.Fatalf("expected the prepared statement to be closed exactly once, closed %d times", got)
+	}
+}
@@ -0,0 +1,196 @@
+package database
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+
+	// mysqlTimeLayouts 按MySQL DATETIME/TIMESTAMP/DATE列常见的文本格式尝试解析，
+	// 需要在不带小数秒的格式之前尝试带小数秒的格式(DATETIME(N)/TIMESTAMP(N))
+	mysqlTimeLayouts = []string{
+		"2006-01-02 15:04:05.999999",
+		"2006-01-02 15:04:05",
+		"2006-01-02T15:04:05.999999",
+		"2006-01-02T15:04:05",
+		"2006-01-02",
+	}
+
+	fieldIndexCache sync.Map // reflect.Type -> map[string][]int
+)
+
+// ScanInto 将QueryRows的结果按列名匹配到dest([]T)的字段中，字段匹配顺序为`db`标签，
+// 其次为导出字段名(不区分大小写)，支持递归匹配内嵌结构体
+func (qr *QueryRows) ScanInto(dest interface{}) error {
+	destVal := reflect.ValueOf(dest)
+	if destVal.Kind() != reflect.Ptr || destVal.IsNil() {
+		return fmt.Errorf("scan into requires a non-nil pointer to a slice, got %T", dest)
+	}
+
+	sliceVal := destVal.Elem()
+	if sliceVal.Kind() != reflect.Slice {
+		return fmt.Errorf("scan into requires a pointer to a slice, got %T", dest)
+	}
+
+	elemType := sliceVal.Type().Elem()
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("scan into requires a slice of structs, got %s", elemType.Kind())
+	}
+
+	fieldIndex := fieldIndexForType(elemType)
+	result := reflect.MakeSlice(sliceVal.Type(), 0, len(qr.Records))
+	for _, record := range qr.Records {
+		elem := reflect.New(elemType).Elem()
+		if err := setFieldsFromRecord(elem, fieldIndex, record); err != nil {
+			return err
+		}
+		result = reflect.Append(result, elem)
+	}
+
+	sliceVal.Set(result)
+	return nil
+}
+
+// ScanInto 将QueryRow的结果按列名匹配到dest(*T)的字段中，匹配规则同QueryRows.ScanInto
+func (qr *QueryRow) ScanInto(dest interface{}) error {
+	destVal := reflect.ValueOf(dest)
+	if destVal.Kind() != reflect.Ptr || destVal.IsNil() {
+		return fmt.Errorf("scan into requires a non-nil pointer to a struct, got %T", dest)
+	}
+
+	structVal := destVal.Elem()
+	if structVal.Kind() != reflect.Struct {
+		return fmt.Errorf("scan into requires a pointer to a struct, got %T", dest)
+	}
+
+	fieldIndex := fieldIndexForType(structVal.Type())
+	return setFieldsFromRecord(structVal, fieldIndex, qr.Record)
+}
+
+// fieldIndexForType 构建列名(小写)到结构体字段路径的映射，按reflect.Type缓存
+func fieldIndexForType(t reflect.Type) map[string][]int {
+	if cached, ok := fieldIndexCache.Load(t); ok {
+		return cached.(map[string][]int)
+	}
+
+	index := make(map[string][]int)
+	buildFieldIndex(t, nil, index)
+	fieldIndexCache.Store(t, index)
+	return index
+}
+
+func buildFieldIndex(t reflect.Type, prefix []int, index map[string][]int) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		path := append(append([]int{}, prefix...), i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && field.Type != timeType {
+			buildFieldIndex(field.Type, path, index)
+			continue
+		}
+
+		name := field.Tag.Get("db")
+		if name == "" {
+			name = field.Name
+		}
+		index[strings.ToLower(name)] = path
+	}
+}
+
+func setFieldsFromRecord(structVal reflect.Value, fieldIndex map[string][]int, record map[string]interface{}) error {
+	for column, value := range record {
+		path, ok := fieldIndex[strings.ToLower(column)]
+		if !ok {
+			continue
+		}
+
+		fieldVal := fieldByIndexAlloc(structVal, path)
+		if err := setFieldValue(fieldVal, value); err != nil {
+			return fmt.Errorf("scan column %q into field failed <-- %s", column, err.Error())
+		}
+	}
+	return nil
+}
+
+// fieldByIndexAlloc 与 reflect.Value.FieldByIndex 相同，但在中间指针为nil时自动分配
+func fieldByIndexAlloc(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+func setFieldValue(fieldVal reflect.Value, value interface{}) error {
+	if value == nil {
+		fieldVal.Set(reflect.Zero(fieldVal.Type()))
+		return nil
+	}
+
+	if fieldVal.Type() == timeType {
+		t, err := parseMySQLTime(value)
+		if err != nil {
+			return err
+		}
+		fieldVal.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	val := reflect.ValueOf(value)
+	if val.Type().AssignableTo(fieldVal.Type()) {
+		fieldVal.Set(val)
+		return nil
+	}
+
+	if val.Type().ConvertibleTo(fieldVal.Type()) &&
+		isNumericKind(val.Kind()) && isNumericKind(fieldVal.Kind()) {
+		fieldVal.Set(val.Convert(fieldVal.Type()))
+		return nil
+	}
+
+	return fmt.Errorf("cannot assign %s to field of type %s", val.Type(), fieldVal.Type())
+}
+
+func isNumericKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func parseMySQLTime(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		var lastErr error
+		for _, layout := range mysqlTimeLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, nil
+			} else {
+				lastErr = err
+			}
+		}
+		return time.Time{}, fmt.Errorf("parse time %q failed <-- %s", v, lastErr.Error())
+	default:
+		return time.Time{}, fmt.Errorf("cannot parse %T as time.Time", value)
+	}
+}
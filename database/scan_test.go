@@ -0,0 +1,113 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestParseMySQLTimeFractionalSeconds(t *testing.T) {
+	cases := []string{
+		"2026-07-28 10:30:00.123456",
+		"2026-07-28 10:30:00.5",
+		"2026-07-28 10:30:00",
+		"2026-07-28",
+	}
+
+	for _, raw := range cases {
+		if _, err := parseMySQLTime(raw); err != nil {
+			t.Errorf("parseMySQLTime(%q) failed: %s", raw, err)
+		}
+	}
+}
+
+// fractionalDatetimeDriver is a minimal fake driver whose single DATETIME
+// column carries a fractional-second value, so tests can drive ScanInto
+// through the real QueryRowWithArgs/getDataType field-construction path
+// instead of hand-building a Field.
+type fractionalDatetimeDriver struct{}
+
+func (fractionalDatetimeDriver) Open(name string) (driver.Conn, error) {
+	return fractionalDatetimeConn{}, nil
+}
+
+type fractionalDatetimeConn struct{}
+
+func (fractionalDatetimeConn) Prepare(query string) (driver.Stmt, error) {
+	return fractionalDatetimeStmt{}, nil
+}
+func (fractionalDatetimeConn) Close() error { return nil }
+func (fractionalDatetimeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("tx not supported")
+}
+
+type fractionalDatetimeStmt struct{}
+
+func (fractionalDatetimeStmt) Close() error  { return nil }
+func (fractionalDatetimeStmt) NumInput() int { return -1 }
+func (fractionalDatetimeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("exec not supported by fractionalDatetimeStmt")
+}
+func (fractionalDatetimeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &fractionalDatetimeRows{}, nil
+}
+
+type fractionalDatetimeRows struct {
+	done bool
+}
+
+func (r *fractionalDatetimeRows) Columns() []string { return []string{"created_at"} }
+func (r *fractionalDatetimeRows) ColumnTypeDatabaseTypeName(index int) string {
+	return "DATETIME"
+}
+func (r *fractionalDatetimeRows) Close() error { return nil }
+func (r *fractionalDatetimeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	dest[0] = "2026-07-28 10:30:00.123456"
+	r.done = true
+	return nil
+}
+
+func TestQueryRowScanIntoParsesFractionalDatetimeString(t *testing.T) {
+	type Event struct {
+		CreatedAt time.Time `db:"created_at"`
+	}
+
+	sql.Register("fractionalDatetimeTestDriver", fractionalDatetimeDriver{})
+	db, err := sql.Open("fractionalDatetimeTestDriver", "")
+	if err != nil {
+		t.Fatalf("open fake driver failed: %s", err)
+	}
+	defer db.Close()
+
+	// ParseTime defaults to false, so the DATETIME column must come back as
+	// "string" in Fields and a raw string in Record, matching getDataType.
+	m := &MySQL{stmtDB: db}
+	row, err := m.QueryRowWithArgs(context.Background(), "SELECT created_at FROM events WHERE id = ?", 1)
+	if err != nil {
+		t.Fatalf("QueryRowWithArgs failed: %s", err)
+	}
+
+	if got := row.Fields[0].Type; got != "string" {
+		t.Fatalf("Fields[0].Type = %q, want %q to match the raw string actually placed in Record", got, "string")
+	}
+	if _, ok := row.Record["created_at"].(string); !ok {
+		t.Fatalf("Record[%q] = %T, want string", "created_at", row.Record["created_at"])
+	}
+
+	var event Event
+	if err := row.ScanInto(&event); err != nil {
+		t.Fatalf("ScanInto failed: %s", err)
+	}
+
+	want := time.Date(2026, 7, 28, 10, 30, 0, 123456000, time.UTC)
+	if !event.CreatedAt.Equal(want) {
+		t.Fatalf("CreatedAt = %v, want %v", event.CreatedAt, want)
+	}
+}
@@ -0,0 +1,190 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const (
+	mysqlErrLockWaitTimeout = 1205
+	mysqlErrDeadlock        = 1213
+
+	defaultTxMaxRetries = 3
+	defaultTxRetryBase  = 50 * time.Millisecond
+)
+
+// Tx 数据库事务，提供与MySQL一致的查询/执行接口
+type Tx struct {
+	rawTx     *sql.Tx
+	parseTime bool
+}
+
+// QueryRows 在事务中执行MySQL Query语句，返回多条数据
+func (t *Tx) QueryRows(ctx context.Context, querySQL string, args ...interface{}) (queryRows *QueryRows, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("query rows in tx failed <-- %w", err)
+		}
+	}()
+
+	stmt, err := t.rawTx.PrepareContext(ctx, querySQL)
+	if err != nil {
+		return
+	}
+	defer stmt.Close()
+
+	rawRows, err := stmt.QueryContext(ctx, args...)
+	if rawRows != nil {
+		defer rawRows.Close()
+	}
+	if err != nil {
+		return
+	}
+
+	colTypes, err := rawRows.ColumnTypes()
+	if err != nil {
+		return
+	}
+
+	fields := make([]Field, 0, len(colTypes))
+	for _, colType := range colTypes {
+		fields = append(fields, Field{
+			Name:   colType.Name(),
+			Type:   getDataType(colType.DatabaseTypeName(), t.parseTime),
+			DBType: colType.DatabaseTypeName(),
+		})
+	}
+
+	queryRows = newQueryRows()
+	queryRows.Fields = fields
+	for rawRows.Next() {
+		receiver := createReceiver(fields, t.parseTime)
+		err = rawRows.Scan(receiver...)
+		if err != nil {
+			err = fmt.Errorf("scan rows failed <-- %w", err)
+			return
+		}
+
+		queryRows.Records = append(queryRows.Records, getRecordFromReceiver(receiver, fields, t.parseTime))
+	}
+	return
+}
+
+// QueryRow 在事务中执行MySQL Query语句，返回１条或０条数据
+func (t *Tx) QueryRow(ctx context.Context, querySQL string, args ...interface{}) (row *QueryRow, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("query row in tx failed <-- %w", err)
+		}
+	}()
+
+	queryRows, err := t.QueryRows(ctx, querySQL, args...)
+	if err != nil || queryRows == nil {
+		return
+	}
+
+	if len(queryRows.Records) < 1 {
+		return
+	}
+
+	row = newQueryRow()
+	row.Fields = queryRows.Fields
+	row.Record = queryRows.Records[0]
+
+	return
+}
+
+// Exec 在事务中执行MySQL的INSERT/UPDATE/DELETE语句
+func (t *Tx) Exec(ctx context.Context, execSQL string, args ...interface{}) (affected, lastInsertID int64, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("exec in tx failed <-- %w", err)
+		}
+	}()
+
+	stmt, err := t.rawTx.PrepareContext(ctx, execSQL)
+	if err != nil {
+		return
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, args...)
+	if err != nil {
+		return
+	}
+
+	affected, err = result.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	lastInsertID, err = result.LastInsertId()
+	return
+}
+
+// WithTx 在事务中执行fn，fn返回nil时提交，返回错误或发生panic时回滚
+// 当MySQL返回死锁(1213)或锁等待超时(1205)错误时，按指数退避自动重试
+func (m *MySQL) WithTx(ctx context.Context, opts *sql.TxOptions, fn func(tx *Tx) error) (err error) {
+	for attempt := 0; attempt <= defaultTxMaxRetries; attempt++ {
+		err = m.runTxOnce(ctx, opts, fn)
+		if err == nil || !isRetryableTxError(err) || attempt == defaultTxMaxRetries {
+			return err
+		}
+
+		backoff := defaultTxRetryBase * time.Duration(1<<uint(attempt))
+		backoff += time.Duration(rand.Int63n(int64(defaultTxRetryBase)))
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return
+}
+
+func (m *MySQL) runTxOnce(ctx context.Context, opts *sql.TxOptions, fn func(tx *Tx) error) (err error) {
+	rawTx, err := m.stmtDB.BeginTx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("begin tx failed <-- %w", err)
+	}
+
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		if rbErr := rawTx.Rollback(); rbErr != nil && rbErr != sql.ErrTxDone {
+			err = fmt.Errorf("%w (rollback failed <-- %s)", err, rbErr.Error())
+		}
+	}()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("tx panic: %v", r)
+		}
+	}()
+
+	if err = fn(&Tx{rawTx: rawTx, parseTime: m.ParseTime}); err != nil {
+		return
+	}
+
+	if err = rawTx.Commit(); err != nil {
+		return fmt.Errorf("commit tx failed <-- %w", err)
+	}
+	committed = true
+	return
+}
+
+func isRetryableTxError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return false
+	}
+	return mysqlErr.Number == mysqlErrDeadlock || mysqlErr.Number == mysqlErrLockWaitTimeout
+}
@@ -0,0 +1,162 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// deadlockOnceDriver is a minimal fake driver whose Exec fails `failures`
+// times with a MySQL deadlock error (1213) before succeeding, so tests can
+// prove WithTx actually retries on a real *mysql.MySQLError surfacing
+// through Tx.Exec.
+type deadlockOnceDriver struct {
+	failures int32
+}
+
+func (d *deadlockOnceDriver) Open(name string) (driver.Conn, error) {
+	return &deadlockOnceConn{driver: d}, nil
+}
+
+type deadlockOnceConn struct {
+	driver *deadlockOnceDriver
+}
+
+func (c *deadlockOnceConn) Prepare(query string) (driver.Stmt, error) {
+	return &deadlockOnceStmt{conn: c}, nil
+}
+func (c *deadlockOnceConn) Close() error              { return nil }
+func (c *deadlockOnceConn) Begin() (driver.Tx, error) { return deadlockOnceTx{}, nil }
+
+type deadlockOnceTx struct{}
+
+func (deadlockOnceTx) Commit() error   { return nil }
+func (deadlockOnceTx) Rollback() error { return nil }
+
+type deadlockOnceStmt struct {
+	conn *deadlockOnceConn
+}
+
+func (s *deadlockOnceStmt) Close() error  { return nil }
+func (s *deadlockOnceStmt) NumInput() int { return -1 }
+
+func (s *deadlockOnceStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if atomic.AddInt32(&s.conn.driver.failures, -1) >= 0 {
+		return nil, &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}
+	}
+	return fakeResult{}, nil
+}
+
+func (s *deadlockOnceStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, errors.New("query not supported by fake driver")
+}
+
+type fakeResult struct{}
+
+func (fakeResult) LastInsertId() (int64, error) { return 0, nil }
+func (fakeResult) RowsAffected() (int64, error) { return 1, nil }
+
+func TestWithTxRetriesOnDeadlockError(t *testing.T) {
+	drv := &deadlockOnceDriver{failures: 1}
+	sql.Register("deadlockOnceTestDriver", drv)
+
+	db, err := sql.Open("deadlockOnceTestDriver", "")
+	if err != nil {
+		t.Fatalf("open fake driver failed: %s", err)
+	}
+	defer db.Close()
+
+	m := &MySQL{stmtDB: db}
+
+	attempts := 0
+	err = m.WithTx(context.Background(), nil, func(tx *Tx) error {
+		attempts++
+		_, _, err := tx.Exec(context.Background(), "UPDATE t SET x = 1")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("WithTx returned error after retry: %s", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected fn to run twice (1 failure + 1 retry), ran %d times", attempts)
+	}
+}
+
+// commitFailsOnceDriver is a minimal fake driver whose transaction Commit
+// fails `failures` times with a MySQL deadlock error (1213) before
+// succeeding, simulating a cluster certification failure surfaced at
+// COMMIT time (e.g. Galera/XtraDB Cluster).
+type commitFailsOnceDriver struct {
+	failures int32
+}
+
+func (d *commitFailsOnceDriver) Open(name string) (driver.Conn, error) {
+	return &commitFailsOnceConn{driver: d}, nil
+}
+
+type commitFailsOnceConn struct {
+	driver *commitFailsOnceDriver
+}
+
+func (c *commitFailsOnceConn) Prepare(query string) (driver.Stmt, error) {
+	return &deadlockOnceStmt{conn: &deadlockOnceConn{driver: &deadlockOnceDriver{}}}, nil
+}
+func (c *commitFailsOnceConn) Close() error { return nil }
+func (c *commitFailsOnceConn) Begin() (driver.Tx, error) {
+	return &commitFailsOnceTx{conn: c}, nil
+}
+
+type commitFailsOnceTx struct {
+	conn *commitFailsOnceConn
+}
+
+func (t *commitFailsOnceTx) Commit() error {
+	if atomic.AddInt32(&t.conn.driver.failures, -1) >= 0 {
+		return &mysql.MySQLError{Number: 1213, Message: "Deadlock: cluster certification failure"}
+	}
+	return nil
+}
+func (t *commitFailsOnceTx) Rollback() error { return nil }
+
+func TestWithTxRetriesOnDeadlockAtCommit(t *testing.T) {
+	drv := &commitFailsOnceDriver{failures: 1}
+	sql.Register("commitFailsOnceTestDriver", drv)
+
+	db, err := sql.Open("commitFailsOnceTestDriver", "")
+	if err != nil {
+		t.Fatalf("open fake driver failed: %s", err)
+	}
+	defer db.Close()
+
+	m := &MySQL{stmtDB: db}
+
+	attempts := 0
+	err = m.WithTx(context.Background(), nil, func(tx *Tx) error {
+		attempts++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithTx returned error after retry: %s", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected fn to run twice (1 failed commit + 1 retry), ran %d times", attempts)
+	}
+}
+
+func TestIsRetryableTxErrorUnwrapsWrappedError(t *testing.T) {
+	wrapped := fmt.Errorf("exec in tx failed <-- %w", &mysql.MySQLError{Number: 1213, Message: "deadlock"})
+	if !isRetryableTxError(wrapped) {
+		t.Fatal("expected wrapped deadlock error to be retryable")
+	}
+
+	nonRetryable := fmt.Errorf("exec in tx failed <-- %w", &mysql.MySQLError{Number: 1062, Message: "duplicate entry"})
+	if isRetryableTxError(nonRetryable) {
+		t.Fatal("expected non-deadlock MySQL error to not be retryable")
+	}
+}
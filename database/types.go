@@ -0,0 +1,213 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"sync"
+)
+
+// typeMapping 描述一个数据库列类型到Go接收者的映射。
+// textVariant非nil时，表示该映射在parseTime关闭时应换用的文本形式(如DATE/
+// DATETIME/TIMESTAMP默认按原文本接收)；RegisterTypeMapping注册的是完整替换，
+// 不带textVariant，因此调用方的自定义映射无论parseTime开关与否都会生效。
+type typeMapping struct {
+	goType      string
+	newReceiver func() interface{}
+	extract     func(interface{}) interface{}
+	textVariant *typeMapping
+}
+
+// resolve 按parseTime选择该使用goType/newReceiver/extract本身还是textVariant
+func (m typeMapping) resolve(parseTime bool) typeMapping {
+	if !parseTime && m.textVariant != nil {
+		return *m.textVariant
+	}
+	return m
+}
+
+var (
+	typeMappingMu sync.RWMutex
+	typeMappings  = defaultTypeMappings()
+)
+
+// RegisterTypeMapping 注册dbType到goType的映射及对应的接收者/取值函数，
+// 调用方可借此扩展自定义列类型(如空间类型)而无需修改本库。注册后的映射完整
+// 替换该dbType原有的映射(包括内置的parseTime文本特例)，对所有parseTime取值生效
+func RegisterTypeMapping(dbType string, goType string, newReceiver func() interface{}, extract func(interface{}) interface{}) {
+	typeMappingMu.Lock()
+	defer typeMappingMu.Unlock()
+	typeMappings[strings.ToUpper(dbType)] = typeMapping{goType: goType, newReceiver: newReceiver, extract: extract}
+}
+
+func lookupTypeMapping(dbColType string, parseTime bool) (typeMapping, bool) {
+	typeMappingMu.RLock()
+	defer typeMappingMu.RUnlock()
+	m, ok := typeMappings[strings.ToUpper(dbColType)]
+	if !ok {
+		return typeMapping{}, false
+	}
+	return m.resolve(parseTime), true
+}
+
+// getDataType Common type include "string", "int64", "uint64", "float64", "bool", "decimal", "time", "bytes", "json"
+func getDataType(dbColType string, parseTime bool) (colType string) {
+	if m, ok := lookupTypeMapping(dbColType, parseTime); ok {
+		return m.goType
+	}
+	return "string"
+}
+
+func receiverFor(dbColType string, parseTime bool) func() interface{} {
+	if m, ok := lookupTypeMapping(dbColType, parseTime); ok {
+		return m.newReceiver
+	}
+	return newNullString
+}
+
+func extractFor(dbColType string, parseTime bool) func(interface{}) interface{} {
+	if m, ok := lookupTypeMapping(dbColType, parseTime); ok {
+		return m.extract
+	}
+	return extractNullString
+}
+
+func newNullString() interface{}  { return new(sql.NullString) }
+func newNullInt64() interface{}   { return new(sql.NullInt64) }
+func newNullFloat64() interface{} { return new(sql.NullFloat64) }
+func newNullBool() interface{}    { return new(sql.NullBool) }
+func newNullDecimal() interface{} { return new(NullDecimal) }
+func newNullUint64() interface{}  { return new(NullUint64) }
+func newNullTime() interface{}    { return new(sql.NullTime) }
+func newRawBytes() interface{}    { return new(sql.RawBytes) }
+
+func extractNullString(value interface{}) interface{} {
+	nullVal := value.(*sql.NullString)
+	if !nullVal.Valid {
+		return nil
+	}
+	return nullVal.String
+}
+
+func extractNullInt64(value interface{}) interface{} {
+	nullVal := value.(*sql.NullInt64)
+	if !nullVal.Valid {
+		return nil
+	}
+	return nullVal.Int64
+}
+
+func extractNullFloat64(value interface{}) interface{} {
+	nullVal := value.(*sql.NullFloat64)
+	if !nullVal.Valid {
+		return nil
+	}
+	return nullVal.Float64
+}
+
+func extractNullBool(value interface{}) interface{} {
+	nullVal := value.(*sql.NullBool)
+	if !nullVal.Valid {
+		return nil
+	}
+	return nullVal.Bool
+}
+
+func extractNullDecimal(value interface{}) interface{} {
+	nullVal := value.(*NullDecimal)
+	if !nullVal.Valid {
+		return nil
+	}
+	return nullVal.String
+}
+
+func extractNullUint64(value interface{}) interface{} {
+	nullVal := value.(*NullUint64)
+	if !nullVal.Valid {
+		return nil
+	}
+	return nullVal.Uint64
+}
+
+func extractNullTime(value interface{}) interface{} {
+	nullVal := value.(*sql.NullTime)
+	if !nullVal.Valid {
+		return nil
+	}
+	return nullVal.Time
+}
+
+func extractBytes(value interface{}) interface{} {
+	raw := value.(*sql.RawBytes)
+	if *raw == nil {
+		return nil
+	}
+	return append([]byte(nil), *raw...)
+}
+
+func extractJSON(value interface{}) interface{} {
+	raw := value.(*sql.RawBytes)
+	if *raw == nil {
+		return nil
+	}
+	return json.RawMessage(append([]byte(nil), *raw...))
+}
+
+func defaultTypeMappings() map[string]typeMapping {
+	str := typeMapping{goType: "string", newReceiver: newNullString, extract: extractNullString}
+	i64 := typeMapping{goType: "int64", newReceiver: newNullInt64, extract: extractNullInt64}
+	f64 := typeMapping{goType: "float64", newReceiver: newNullFloat64, extract: extractNullFloat64}
+	boolean := typeMapping{goType: "bool", newReceiver: newNullBool, extract: extractNullBool}
+	decimal := typeMapping{goType: "decimal", newReceiver: newNullDecimal, extract: extractNullDecimal}
+	u64 := typeMapping{goType: "uint64", newReceiver: newNullUint64, extract: extractNullUint64}
+	// tm默认按time.Time接收(parseTime=true)，parseTime=false时换用str按原文本接收
+	tm := typeMapping{goType: "time", newReceiver: newNullTime, extract: extractNullTime, textVariant: &str}
+	bytes := typeMapping{goType: "bytes", newReceiver: newRawBytes, extract: extractBytes}
+	jsonType := typeMapping{goType: "json", newReceiver: newRawBytes, extract: extractJSON}
+
+	return map[string]typeMapping{
+		"VARCHAR":  str,
+		"TEXT":     str,
+		"NVARCHAR": str,
+		"CHAR":     str,
+		"ENUM":     str,
+		"SET":      str,
+		"TIME":     str,
+
+		"TINYINT":           i64,
+		"SMALLINT":          i64,
+		"MEDIUMINT":         i64,
+		"INT":               i64,
+		"BIGINT":            i64,
+		"UNSIGNED TINYINT":  u64,
+		"UNSIGNED SMALLINT": u64,
+		"UNSIGNED INT":      u64,
+		"UNSIGNED BIGINT":   u64,
+		// "UNSIGNED MEDIUMINT" is kept for symmetry but never actually matches:
+		// go-sql-driver/mysql's fieldTypeInt24 case reports plain "MEDIUMINT"
+		// regardless of the column's unsigned flag, so unsigned MEDIUMINT columns
+		// fall through to the signed "MEDIUMINT": i64 entry above. Harmless since
+		// MEDIUMINT UNSIGNED's max value (16777215) fits in int64.
+		"UNSIGNED MEDIUMINT": u64,
+
+		"FLOAT":  f64,
+		"DOUBLE": f64,
+
+		"BOOL": boolean,
+
+		"DECIMAL": decimal,
+
+		"DATE":      tm,
+		"DATETIME":  tm,
+		"TIMESTAMP": tm,
+
+		"BLOB":       bytes,
+		"TINYBLOB":   bytes,
+		"MEDIUMBLOB": bytes,
+		"LONGBLOB":   bytes,
+		"BINARY":     bytes,
+		"VARBINARY":  bytes,
+
+		"JSON": jsonType,
+	}
+}
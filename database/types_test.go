@@ -0,0 +1,82 @@
+package database
+
+import "testing"
+
+func TestGetDataTypeUnsignedIntegers(t *testing.T) {
+	// go-sql-driver/mysql reports unsigned integer columns with the
+	// "UNSIGNED" token as a prefix, e.g. "UNSIGNED BIGINT".
+	cases := map[string]string{
+		"UNSIGNED TINYINT":   "uint64",
+		"UNSIGNED SMALLINT":  "uint64",
+		"UNSIGNED MEDIUMINT": "uint64",
+		"UNSIGNED INT":       "uint64",
+		"UNSIGNED BIGINT":    "uint64",
+		"unsigned bigint":    "uint64",
+		"BIGINT":             "int64",
+		"DECIMAL":            "decimal",
+		"JSON":               "json",
+		"BLOB":               "bytes",
+	}
+
+	for dbType, want := range cases {
+		if got := getDataType(dbType, false); got != want {
+			t.Errorf("getDataType(%q, false) = %q, want %q", dbType, got, want)
+		}
+	}
+}
+
+func TestGetDataTypeTemporalMatchesParseTimeSetting(t *testing.T) {
+	// Field.Type must always match what QueryRows/QueryRow actually places in
+	// Record: a raw string when ParseTime is off (the default), time.Time
+	// when it's on.
+	for _, dbType := range []string{"DATE", "DATETIME", "TIMESTAMP"} {
+		if got := getDataType(dbType, false); got != "string" {
+			t.Errorf("getDataType(%q, false) = %q, want %q", dbType, got, "string")
+		}
+		if got := getDataType(dbType, true); got != "time" {
+			t.Errorf("getDataType(%q, true) = %q, want %q", dbType, got, "time")
+		}
+	}
+}
+
+func TestGetDataTypeUnknownFallsBackToString(t *testing.T) {
+	if got := getDataType("GEOMETRY", false); got != "string" {
+		t.Errorf("getDataType(unregistered, false) = %q, want %q", got, "string")
+	}
+	if got := getDataType("GEOMETRY", true); got != "string" {
+		t.Errorf("getDataType(unregistered, true) = %q, want %q", got, "string")
+	}
+}
+
+func TestRegisterTypeMappingOverrideIgnoresParseTime(t *testing.T) {
+	const dbType = "TEST_CUSTOM_TEMPORAL"
+	RegisterTypeMapping(dbType, "custom", newNullString, extractNullString)
+
+	for _, parseTime := range []bool{false, true} {
+		if got := getDataType(dbType, parseTime); got != "custom" {
+			t.Errorf("getDataType(%q, %v) = %q, want %q: a RegisterTypeMapping override must apply regardless of ParseTime",
+				dbType, parseTime, got, "custom")
+		}
+	}
+}
+
+func TestUnsignedBigintReceiverHandlesValuesAboveMaxInt64(t *testing.T) {
+	// math.MaxUint64, which go-sql-driver/mysql decodes as a Go uint64 and
+	// which sql.NullInt64 cannot represent (strconv.ParseInt overflows).
+	const aboveMaxInt64 = uint64(18446744073709551615)
+
+	receiver := receiverFor("UNSIGNED BIGINT", false)()
+	scanner, ok := receiver.(interface{ Scan(interface{}) error })
+	if !ok {
+		t.Fatalf("receiver for UNSIGNED BIGINT does not implement sql.Scanner: %T", receiver)
+	}
+
+	if err := scanner.Scan(aboveMaxInt64); err != nil {
+		t.Fatalf("Scan(%d) failed: %s", aboveMaxInt64, err)
+	}
+
+	got := extractFor("UNSIGNED BIGINT", false)(receiver)
+	if got != aboveMaxInt64 {
+		t.Fatalf("extractFor(UNSIGNED BIGINT) = %v, want %v", got, aboveMaxInt64)
+	}
+}